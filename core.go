@@ -8,15 +8,17 @@ import (
 	"syscall"
 
 	"github.com/pink-tools/pink-core/log"
+	"github.com/pink-tools/pink-core/supervise"
 )
 
 // Config for Run()
 type Config struct {
-	Name       string
-	Version    string
-	Usage      string             // optional, auto-generated if empty
-	Commands   map[string]Command // subcommands
-	IPCHandler func(cmd string) string // custom IPC commands handler
+	Name        string
+	Version     string
+	Usage       string             // optional, auto-generated if empty
+	Commands    map[string]Command // subcommands
+	IPCHandler  IPCHandler         // custom IPC Invoke commands, keyed by name
+	AllowedUIDs []int              // extra UIDs allowed to connect over the IPC socket, beyond our own
 }
 
 // Command is a CLI subcommand
@@ -33,6 +35,8 @@ type Command struct {
 //   - IPC listener for graceful shutdown (if main != nil)
 //   - Signal handling (SIGINT, SIGTERM)
 //   - Context cancellation on shutdown
+//   - Stopping any subprocesses started via supervise.Spawn
+//   - Running OnExit hooks, bounded by --drain-timeout (default 10s)
 func Run(cfg Config, main func(ctx context.Context) error) {
 	log.Init(cfg.Name, cfg.Version)
 
@@ -61,13 +65,23 @@ func Run(cfg Config, main func(ctx context.Context) error) {
 	// Daemon mode
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// If we were just respawned by a self-update, confirm health or roll
+	// back, before doing anything else.
+	if selfUpdateOpts != nil {
+		resolvePendingSelfUpdate(ctx, cfg.Name)
+	}
+
 	// Start IPC listener for graceful shutdown
-	ipcCleanup, err := startIPCListener(cfg.Name, cancel, cfg.IPCHandler)
+	ipcCleanup, err := startIPCListener(cfg.Name, cfg.Version, cancel, cfg.IPCHandler, cfg.AllowedUIDs)
 	if err != nil {
 		log.Error(ctx, "failed to start IPC listener", log.Attr{"error", err.Error()})
 		os.Exit(1)
 	}
-	defer ipcCleanup()
+
+	// Background self-update checks
+	if selfUpdateOpts != nil {
+		go runUpdateChecker(ctx, cfg, *selfUpdateOpts)
+	}
 
 	// Signal handling
 	sigCh := make(chan os.Signal, 1)
@@ -83,14 +97,36 @@ func Run(cfg Config, main func(ctx context.Context) error) {
 
 	// Run main
 	log.Info(ctx, "started "+cfg.Version)
-	if err := main(ctx); err != nil {
-		log.Error(ctx, "main exited with error", log.Attr{"error", err.Error()})
+	mainErr := main(ctx)
+
+	// Uniform graceful shutdown, however it was triggered (signal or the
+	// IPC Stop RPC, both of which just cancel ctx and let main return).
+	runExitPhase(ctx, PhasePreStop)
+	runExitPhase(ctx, PhaseStop)
+	supervise.Shutdown()
+	ipcCleanup()
+	runExitPhase(ctx, PhasePostStop)
+	runExitPhase(ctx, PhaseFlush)
+
+	// A self-update swapped our binary and asked us to stop: re-exec into
+	// it now that everything above has shut down cleanly.
+	if selfUpdateOpts != nil && requestedSelfRestart(cfg.Name) {
+		log.Info(ctx, "restarting into updated binary")
+		execSelf(cfg.Name)
+	}
+
+	if mainErr != nil {
+		log.Error(ctx, "main exited with error", log.Attr{"error", mainErr.Error()})
 		os.Exit(1)
 	}
 	log.Info(ctx, "shutdown complete")
 }
 
 func handleCLI(cfg Config) bool {
+	stripDrainTimeoutFlag()
+	if len(os.Args) <= 1 {
+		return false
+	}
 	arg := os.Args[1]
 
 	switch arg {
@@ -136,6 +172,7 @@ func printUsage(cfg Config) {
 	fmt.Println("  --version, -V    Show version")
 	fmt.Println("  --help, -h       Show this help")
 	fmt.Println("  --health         Check if running")
+	fmt.Println("  --drain-timeout  Max time per OnExit hook during shutdown (default 10s)")
 
 	if len(cfg.Commands) > 0 {
 		fmt.Println()
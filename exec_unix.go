@@ -0,0 +1,21 @@
+//go:build unix
+
+package core
+
+import (
+	"context"
+	"os"
+	"syscall"
+
+	"github.com/pink-tools/pink-core/log"
+)
+
+// execSelf replaces the current process image with the binary at
+// BinaryPath(name), preserving argv and environment. On success it never
+// returns.
+func execSelf(name string) {
+	path := BinaryPath(name)
+	if err := syscall.Exec(path, os.Args, os.Environ()); err != nil {
+		log.Error(context.Background(), "re-exec failed", log.Attr{"path", path}, log.Attr{"error", err.Error()})
+	}
+}
@@ -0,0 +1,30 @@
+//go:build windows
+
+package core
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/pink-tools/pink-core/log"
+)
+
+// execSelf cannot replace the current process image on Windows, so it
+// spawns BinaryPath(name) as a new process, sharing this one's stdout and
+// stderr, and exits; the child inherits no lifetime dependency on this
+// process.
+func execSelf(name string) {
+	path := BinaryPath(name)
+	cmd := exec.Command(path, os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+
+	if err := cmd.Start(); err != nil {
+		log.Error(context.Background(), "re-spawn failed", log.Attr{"path", path}, log.Attr{"error", err.Error()})
+		return
+	}
+
+	os.Exit(0)
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pink-tools/pink-core/log"
+)
+
+// Phase orders atexit hooks during shutdown. Hooks run phase by phase, in
+// the order the phases are declared below, and in reverse-registration
+// order within each phase (last registered, first run) so library code
+// that registers late can assume earlier, more fundamental hooks are
+// still available to depend on.
+type Phase int
+
+const (
+	// PhasePreStop runs first, while the rest of the daemon is still up.
+	PhasePreStop Phase = iota
+	// PhaseStop runs the main teardown work (closing connections, etc).
+	PhaseStop
+	// PhasePostStop runs after Stop, once the IPC listener has closed.
+	PhasePostStop
+	// PhaseFlush runs last, immediately before logs are flushed.
+	PhaseFlush
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhasePreStop:
+		return "pre-stop"
+	case PhaseStop:
+		return "stop"
+	case PhasePostStop:
+		return "post-stop"
+	case PhaseFlush:
+		return "flush"
+	default:
+		return "unknown"
+	}
+}
+
+type exitHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+var (
+	exitMu    sync.Mutex
+	exitHooks = map[Phase][]exitHook{}
+
+	// drainTimeout bounds each individual hook; overridden by --drain-timeout.
+	drainTimeout = 10 * time.Second
+)
+
+// stripDrainTimeoutFlag pulls --drain-timeout (or --drain-timeout=VALUE)
+// out of os.Args, applying it to drainTimeout, so the rest of CLI parsing
+// never has to know about it.
+func stripDrainTimeoutFlag() {
+	args := os.Args[1:]
+	out := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+
+		if value, ok := strings.CutPrefix(a, "--drain-timeout="); ok {
+			setDrainTimeout(value)
+			continue
+		}
+		if a == "--drain-timeout" && i+1 < len(args) {
+			setDrainTimeout(args[i+1])
+			i++
+			continue
+		}
+
+		out = append(out, a)
+	}
+
+	os.Args = append(os.Args[:1], out...)
+}
+
+func setDrainTimeout(value string) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return
+	}
+	drainTimeout = d
+}
+
+// OnExit registers fn to run during graceful shutdown, in the given phase.
+// fn is given a context with a timeout of --drain-timeout (10s by default)
+// and should return promptly once that context is done. OnExit is safe to
+// call from init() or at any point before Run's main returns.
+func OnExit(phase Phase, name string, fn func(ctx context.Context) error) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	exitHooks[phase] = append(exitHooks[phase], exitHook{name: name, fn: fn})
+}
+
+// runExitPhase runs every hook registered for phase, most-recently
+// registered first, logging each hook's duration and any error.
+//
+// ctx is only used for logging: it's the daemon's root context, already
+// canceled by the time shutdown runs (that cancellation is what made Run's
+// main return in the first place), so hook timeouts are derived from a
+// fresh background context instead. Deriving WithTimeout from ctx directly
+// would hand every hook an already-done context, giving it zero grace time.
+func runExitPhase(ctx context.Context, phase Phase) {
+	exitMu.Lock()
+	hooks := append([]exitHook(nil), exitHooks[phase]...)
+	exitMu.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+
+		hookCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		start := time.Now()
+		err := h.fn(hookCtx)
+		cancel()
+		elapsed := time.Since(start)
+
+		if err != nil {
+			log.Error(ctx, "atexit hook failed", log.Attr{"phase", phase.String()}, log.Attr{"name", h.name}, log.Attr{"duration", elapsed.String()}, log.Attr{"error", err.Error()})
+			continue
+		}
+		log.Debug(ctx, "ran atexit hook", log.Attr{"phase", phase.String()}, log.Attr{"name", h.name}, log.Attr{"duration", elapsed.String()})
+	}
+}
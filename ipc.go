@@ -1,137 +1,289 @@
 package core
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"net"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/pink-tools/pink-core/log"
+	pinkcorev1 "github.com/pink-tools/pink-core/rpc/pinkcorev1"
 )
 
-// startIPCListener starts TCP listener for graceful shutdown and custom commands
-// Returns cleanup function and error
-func startIPCListener(name string, cancel context.CancelFunc, handler func(string) string) (func(), error) {
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
+// IPCHandler is the shape of Config.IPCHandler: a named dispatch table for
+// user-defined Invoke commands. The handler receives the raw payload the
+// client sent and returns the raw payload to reply with, or an error that
+// is surfaced to the caller as InvokeResponse.Error.
+type IPCHandler = map[string]func(ctx context.Context, payload []byte) ([]byte, error)
+
+// startIPCListener starts the gRPC control-plane listener for graceful
+// shutdown and custom commands. It prefers a unix socket (named pipe on
+// Windows) over the older TCP-plus-port-file transport; see platformListen.
+// Returns cleanup function and error.
+func startIPCListener(name, version string, cancel context.CancelFunc, handler IPCHandler, allowedUIDs []int) (func(), error) {
+	listener, network, address, err := platformListen(name)
 	if err != nil {
-		return nil, fmt.Errorf("listen: %w", err)
+		return nil, err
 	}
+	listener = maybeWrapAuth(listener, network, allowedUIDs)
 
-	// Get assigned port
-	addr := listener.Addr().(*net.TCPAddr)
-	port := addr.Port
+	srv := &ipcServer{cancel: cancel, handler: handler, version: version, started: time.Now()}
+	grpcServer := grpc.NewServer(grpc.ForceServerCodec(pinkcorev1.Codec()))
+	pinkcorev1.RegisterServiceServer(grpcServer, srv)
 
-	// Write port to file
-	portFile := portFilePath(name)
-	if err := os.MkdirAll(filepath.Dir(portFile), 0755); err != nil {
-		listener.Close()
-		return nil, fmt.Errorf("mkdir: %w", err)
-	}
-	if err := os.WriteFile(portFile, []byte(strconv.Itoa(port)), 0644); err != nil {
-		listener.Close()
-		return nil, fmt.Errorf("write port file: %w", err)
-	}
+	unsubscribeLog := log.Subscribe(srv.publishLogEvent)
 
-	// Accept connections
 	go func() {
-		for {
-			conn, err := listener.Accept()
-			if err != nil {
-				return // listener closed
-			}
-			go handleIPCConnection(conn, cancel, handler)
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Debug(context.Background(), "IPC listener stopped", log.Attr{"error", err.Error()})
 		}
 	}()
 
 	cleanup := func() {
-		listener.Close()
-		os.Remove(portFile)
+		unsubscribeLog()
+		grpcServer.GracefulStop()
+		if network == "unix" {
+			os.Remove(address)
+		}
+		os.Remove(portFilePath(name))
 	}
 
 	return cleanup, nil
 }
 
-func handleIPCConnection(conn net.Conn, cancel context.CancelFunc, handler func(string) string) {
-	defer conn.Close()
+// ipcServer implements pinkcorev1.ServiceServer against a running daemon.
+type ipcServer struct {
+	pinkcorev1.UnimplementedServiceServer
+
+	cancel  context.CancelFunc
+	handler IPCHandler
+	version string
+	started time.Time
 
-	reader := bufio.NewReaderSize(conn, 65536)
-	line, err := reader.ReadString('\n')
+	mu     sync.Mutex
+	events []chan *pinkcorev1.Event
+}
+
+func (s *ipcServer) Ping(ctx context.Context, _ *pinkcorev1.PingRequest) (*pinkcorev1.PingResponse, error) {
+	return &pinkcorev1.PingResponse{}, nil
+}
+
+func (s *ipcServer) Stop(ctx context.Context, _ *pinkcorev1.StopRequest) (*pinkcorev1.StopResponse, error) {
+	log.Info(ctx, "received IPC STOP command")
+	s.cancel()
+	return &pinkcorev1.StopResponse{}, nil
+}
+
+func (s *ipcServer) Health(ctx context.Context, _ *pinkcorev1.HealthRequest) (*pinkcorev1.HealthResponse, error) {
+	return &pinkcorev1.HealthResponse{
+		Healthy:       true,
+		Version:       s.version,
+		UptimeSeconds: int64(time.Since(s.started).Seconds()),
+	}, nil
+}
+
+func (s *ipcServer) Dialog(ctx context.Context, req *pinkcorev1.DialogRequest) (*pinkcorev1.DialogResponse, error) {
+	fn, ok := s.handler["dialog"]
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "dialog not handled")
+	}
+	resp, err := fn(ctx, req.DialogJson)
 	if err != nil {
-		return
+		return nil, status.Error(codes.Unknown, err.Error())
 	}
+	return &pinkcorev1.DialogResponse{Choice: string(resp)}, nil
+}
 
-	cmd := strings.TrimSpace(line)
-	switch cmd {
-	case "STOP":
-		log.Info(context.Background(), "received IPC STOP command")
-		conn.Write([]byte("OK\n"))
-		cancel()
-	case "PING":
-		conn.Write([]byte("PONG\n"))
-	default:
-		if handler != nil {
-			response := handler(cmd)
-			conn.Write([]byte(response + "\n"))
-		} else {
-			conn.Write([]byte("UNKNOWN\n"))
+func (s *ipcServer) Invoke(ctx context.Context, req *pinkcorev1.InvokeRequest) (*pinkcorev1.InvokeResponse, error) {
+	fn, ok := s.handler[req.Name]
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "no handler for %q", req.Name)
+	}
+	payload, err := fn(ctx, req.Payload)
+	if err != nil {
+		return &pinkcorev1.InvokeResponse{Error: err.Error()}, nil
+	}
+	return &pinkcorev1.InvokeResponse{Payload: payload}, nil
+}
+
+// Events streams daemon log/progress events until the client disconnects.
+func (s *ipcServer) Events(_ *pinkcorev1.EventsRequest, stream pinkcorev1.Service_EventsServer) error {
+	ch := make(chan *pinkcorev1.Event, 64)
+	s.mu.Lock()
+	s.events = append(s.events, ch)
+	s.mu.Unlock()
+	defer s.removeEventsSub(ch)
+
+	for {
+		select {
+		case ev := <-ch:
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
 		}
 	}
 }
 
-// SendStop sends STOP command via IPC
-func SendStop(name string) error {
-	port, err := readPort(name)
+func (s *ipcServer) removeEventsSub(ch chan *pinkcorev1.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, c := range s.events {
+		if c == ch {
+			s.events = append(s.events[:i], s.events[i+1:]...)
+			return
+		}
+	}
+}
+
+// publishLogEvent is registered with log.Subscribe so every log line this
+// daemon emits also reaches connected Events subscribers. It never blocks:
+// a subscriber that isn't keeping up drops events rather than stalling the
+// caller's log line.
+func (s *ipcServer) publishLogEvent(level, body string) {
+	ev := &pinkcorev1.Event{TimestampUnix: time.Now().Unix(), Level: level, Body: body}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range s.events {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// Client is a typed handle to another pink-tools service's IPC endpoint.
+type Client struct {
+	conn *grpc.ClientConn
+	rpc  pinkcorev1.ServiceClient
+}
+
+// Dial connects to the named service's IPC endpoint. Callers must Close it.
+func Dial(name string) (*Client, error) {
+	target, extraOpts, err := platformDial(name)
 	if err != nil {
-		return fmt.Errorf("not running: %w", err)
+		return nil, err
 	}
 
-	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	opts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(pinkcorev1.Codec())),
+	}, extraOpts...)
+	conn, err := grpc.NewClient(target, opts...)
 	if err != nil {
-		return fmt.Errorf("connect: %w", err)
+		return nil, fmt.Errorf("dial: %w", err)
 	}
-	defer conn.Close()
 
-	conn.Write([]byte("STOP\n"))
+	return &Client{conn: conn, rpc: pinkcorev1.NewServiceClient(conn)}, nil
+}
+
+// Close releases the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Ping checks whether the service is responsive.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.rpc.Ping(ctx, &pinkcorev1.PingRequest{})
+	return err
+}
+
+// Stop requests graceful shutdown of the service.
+func (c *Client) Stop(ctx context.Context) error {
+	_, err := c.rpc.Stop(ctx, &pinkcorev1.StopRequest{})
+	return err
+}
+
+// Health returns the service's self-reported health status.
+func (c *Client) Health(ctx context.Context) (*pinkcorev1.HealthResponse, error) {
+	return c.rpc.Health(ctx, &pinkcorev1.HealthRequest{})
+}
 
-	reader := bufio.NewReader(conn)
-	response, err := reader.ReadString('\n')
+// Dialog forwards a dialog request and returns the user's choice.
+func (c *Client) Dialog(ctx context.Context, dialogJSON string) (string, error) {
+	resp, err := c.rpc.Dialog(ctx, &pinkcorev1.DialogRequest{DialogJson: []byte(dialogJSON)})
 	if err != nil {
-		return fmt.Errorf("read response: %w", err)
+		return "", err
 	}
+	return resp.Choice, nil
+}
 
-	if strings.TrimSpace(response) != "OK" {
-		return fmt.Errorf("unexpected response: %s", response)
+// Invoke calls a user-defined command registered via Config.IPCHandler.
+func (c *Client) Invoke(ctx context.Context, name string, payload []byte) ([]byte, error) {
+	resp, err := c.rpc.Invoke(ctx, &pinkcorev1.InvokeRequest{Name: name, Payload: payload})
+	if err != nil {
+		return nil, err
 	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s: %s", name, resp.Error)
+	}
+	return resp.Payload, nil
+}
 
-	return nil
+// Events subscribes to the service's event stream.
+func (c *Client) Events(ctx context.Context) (pinkcorev1.Service_EventsClient, error) {
+	return c.rpc.Events(ctx, &pinkcorev1.EventsRequest{})
 }
 
-// SendCommand sends a command via IPC and returns response
-func SendCommand(name, cmd string) (string, error) {
-	port, err := readPort(name)
+// SendStop sends STOP via IPC. Kept for callers that predate the typed Client.
+func SendStop(name string) error {
+	c, err := Dial(name)
 	if err != nil {
-		return "", fmt.Errorf("not running: %w", err)
+		return err
 	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return c.Stop(ctx)
+}
 
-	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+// SendCommand invokes a named command via IPC and returns its response.
+// Kept for callers that predate the typed Client and Invoke. STOP and PING
+// are special-cased to the dedicated Stop/Ping RPCs, matching the verbs the
+// pre-gRPC wire protocol handled directly, rather than going through
+// Config.IPCHandler like user-defined verbs do.
+func SendCommand(name, cmd string) (string, error) {
+	c, err := Dial(name)
 	if err != nil {
-		return "", fmt.Errorf("connect: %w", err)
+		return "", err
 	}
-	defer conn.Close()
+	defer c.Close()
 
-	conn.Write([]byte(cmd + "\n"))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	reader := bufio.NewReader(conn)
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return "", fmt.Errorf("read response: %w", err)
+	verb, arg, _ := strings.Cut(cmd, ":")
+
+	switch verb {
+	case "STOP":
+		if err := c.Stop(ctx); err != nil {
+			return "", err
+		}
+		return "OK", nil
+	case "PING":
+		if err := c.Ping(ctx); err != nil {
+			return "", err
+		}
+		return "PONG", nil
 	}
 
-	return strings.TrimSpace(response), nil
+	resp, err := c.Invoke(ctx, verb, []byte(arg))
+	if err != nil {
+		return "", err
+	}
+	return string(resp), nil
 }
 
 // IsOrchestratorRunning checks if pink-orchestrator is running
@@ -146,27 +298,29 @@ func ShowDialog(dialogJSON string) (string, error) {
 	if !IsOrchestratorRunning() {
 		return "", fmt.Errorf("orchestrator not running")
 	}
-	return SendCommand("pink-orchestrator", "dialog:"+dialogJSON)
-}
 
-// IsRunning checks if service is running via IPC
-func IsRunning(name string) bool {
-	port, err := readPort(name)
+	c, err := Dial("pink-orchestrator")
 	if err != nil {
-		return false
+		return "", err
 	}
+	defer c.Close()
 
-	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return c.Dialog(ctx, dialogJSON)
+}
+
+// IsRunning checks if service is running via IPC
+func IsRunning(name string) bool {
+	c, err := Dial(name)
 	if err != nil {
 		return false
 	}
-	defer conn.Close()
-
-	conn.Write([]byte("PING\n"))
+	defer c.Close()
 
-	reader := bufio.NewReader(conn)
-	response, _ := reader.ReadString('\n')
-	return strings.TrimSpace(response) == "PONG"
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return c.Ping(ctx) == nil
 }
 
 func readPort(name string) (int, error) {
@@ -2,6 +2,7 @@ package log
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pink-tools/pink-otel"
 )
@@ -15,7 +16,44 @@ func SetServiceNameWidth(w int)    { otel.SetServiceNameWidth(w) }
 func PrintServiceLog(line string)  { otel.PrintServiceLog(line) }
 func ParseLogMessage(line string) string { return otel.ParseLogMessage(line) }
 
-func Debug(ctx context.Context, body string, attrs ...Attr) { otel.Debug(ctx, body, attrs...) }
-func Info(ctx context.Context, body string, attrs ...Attr)  { otel.Info(ctx, body, attrs...) }
-func Warn(ctx context.Context, body string, attrs ...Attr)  { otel.Warn(ctx, body, attrs...) }
-func Error(ctx context.Context, body string, attrs ...Attr) { otel.Error(ctx, body, attrs...) }
+func Debug(ctx context.Context, body string, attrs ...Attr) { otel.Debug(ctx, body, attrs...); notify("debug", body) }
+func Info(ctx context.Context, body string, attrs ...Attr)  { otel.Info(ctx, body, attrs...); notify("info", body) }
+func Warn(ctx context.Context, body string, attrs ...Attr)  { otel.Warn(ctx, body, attrs...); notify("warn", body) }
+func Error(ctx context.Context, body string, attrs ...Attr) { otel.Error(ctx, body, attrs...); notify("error", body) }
+
+var (
+	subMu sync.Mutex
+	subs  = map[int]func(level, body string){}
+	subID int
+)
+
+// Subscribe registers fn to be called, in addition to the normal otel
+// output, with the level and body of every log line emitted through this
+// package from here on. Used by core's IPC Events RPC to stream logs to a
+// connected client. The returned func unsubscribes.
+func Subscribe(fn func(level, body string)) func() {
+	subMu.Lock()
+	defer subMu.Unlock()
+	id := subID
+	subID++
+	subs[id] = fn
+
+	return func() {
+		subMu.Lock()
+		defer subMu.Unlock()
+		delete(subs, id)
+	}
+}
+
+func notify(level, body string) {
+	subMu.Lock()
+	fns := make([]func(string, string), 0, len(subs))
+	for _, fn := range subs {
+		fns = append(fns, fn)
+	}
+	subMu.Unlock()
+
+	for _, fn := range fns {
+		fn(level, body)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build darwin
+
+package core
+
+import (
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerUID returns the UID of the process on the other end of a unix
+// socket connection, via the LOCAL_PEERCRED socket option (there is no
+// SO_PEERCRED/getpeereid(3) equivalent in x/sys/unix on Darwin).
+func peerUID(conn net.Conn) (int, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, fmt.Errorf("not a unix socket connection")
+	}
+
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
+
+	var xucred *unix.Xucred
+	var sockErr error
+	err = raw.Control(func(fd uintptr) {
+		xucred, sockErr = unix.GetsockoptXucred(int(fd), unix.SOL_LOCAL, unix.LOCAL_PEERCRED)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if sockErr != nil {
+		return 0, sockErr
+	}
+
+	return int(xucred.Uid), nil
+}
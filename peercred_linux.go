@@ -0,0 +1,37 @@
+//go:build linux
+
+package core
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// peerUID returns the UID of the process on the other end of a unix
+// socket connection, via SO_PEERCRED.
+func peerUID(conn net.Conn) (int, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, fmt.Errorf("not a unix socket connection")
+	}
+
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return 0, err
+	}
+
+	var ucred *syscall.Ucred
+	var sockErr error
+	err = raw.Control(func(fd uintptr) {
+		ucred, sockErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if sockErr != nil {
+		return 0, sockErr
+	}
+
+	return int(ucred.Uid), nil
+}
@@ -0,0 +1,6 @@
+// Package rpc holds the pink-core control-plane proto and its hand-written
+// Go bindings. service.proto documents the schema for Go readers of this
+// package, not as a cross-language IDL; pinkcorev1's .go files are
+// maintained by hand alongside it (see pinkcorev1/codec.go for why protoc
+// isn't actually involved), so there is no `go generate` step.
+package rpc
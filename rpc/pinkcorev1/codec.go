@@ -0,0 +1,37 @@
+package pinkcorev1
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// Codec is the wire codec every pink-core IPC client and server must use for
+// this service: a grpc/encoding.Codec backed by encoding/gob rather than
+// real protobuf. The types in service_types.go carry protoc-style field
+// names to match service.proto, but nothing here runs them through
+// proto.Marshal, so callers must force this codec explicitly (grpc's default
+// codec assumes proto.Message and will fail to marshal them) via
+// grpc.ForceServerCodec on the server and grpc.ForceCodec on the client.
+func Codec() encoding.Codec {
+	return gobCodec{}
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+func (gobCodec) Name() string {
+	return "pinkcore-gob"
+}
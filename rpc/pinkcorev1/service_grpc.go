@@ -0,0 +1,260 @@
+// Hand-maintained client/server bindings for the Service gRPC service
+// described by service.proto. This mirrors the shape protoc-gen-go-grpc
+// would produce, but it is not generated: pink-core's messages aren't real
+// protobuf (see codec.go), so there's no generator that understands them.
+// Keep this in sync with service.proto by hand when the RPC surface changes.
+
+package pinkcorev1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceClient is the client API for Service.
+type ServiceClient interface {
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
+	Dialog(ctx context.Context, in *DialogRequest, opts ...grpc.CallOption) (*DialogResponse, error)
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (Service_EventsClient, error)
+	Invoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error)
+}
+
+type serviceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewServiceClient(cc grpc.ClientConnInterface) ServiceClient {
+	return &serviceClient{cc}
+}
+
+func (c *serviceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	if err := c.cc.Invoke(ctx, "/pinkcore.v1.Service/Ping", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
+	out := new(StopResponse)
+	if err := c.cc.Invoke(ctx, "/pinkcore.v1.Service/Stop", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) Dialog(ctx context.Context, in *DialogRequest, opts ...grpc.CallOption) (*DialogResponse, error) {
+	out := new(DialogResponse)
+	if err := c.cc.Invoke(ctx, "/pinkcore.v1.Service/Dialog", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	if err := c.cc.Invoke(ctx, "/pinkcore.v1.Service/Health", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (Service_EventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Service_serviceDesc.Streams[0], "/pinkcore.v1.Service/Events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+func (c *serviceClient) Invoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error) {
+	out := new(InvokeResponse)
+	if err := c.cc.Invoke(ctx, "/pinkcore.v1.Service/Invoke", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Service_EventsClient is the client-side stream handle returned by Events.
+type Service_EventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type serviceEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ServiceServer is the server API for Service. Implementations embed
+// UnimplementedServiceServer to stay forward compatible as the proto grows.
+type ServiceServer interface {
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	Stop(context.Context, *StopRequest) (*StopResponse, error)
+	Dialog(context.Context, *DialogRequest) (*DialogResponse, error)
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	Events(*EventsRequest, Service_EventsServer) error
+	Invoke(context.Context, *InvokeRequest) (*InvokeResponse, error)
+}
+
+// UnimplementedServiceServer must be embedded for forward compatibility.
+type UnimplementedServiceServer struct{}
+
+func (UnimplementedServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, grpcNotImplemented("Ping")
+}
+func (UnimplementedServiceServer) Stop(context.Context, *StopRequest) (*StopResponse, error) {
+	return nil, grpcNotImplemented("Stop")
+}
+func (UnimplementedServiceServer) Dialog(context.Context, *DialogRequest) (*DialogResponse, error) {
+	return nil, grpcNotImplemented("Dialog")
+}
+func (UnimplementedServiceServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
+	return nil, grpcNotImplemented("Health")
+}
+func (UnimplementedServiceServer) Events(*EventsRequest, Service_EventsServer) error {
+	return grpcNotImplemented("Events")
+}
+func (UnimplementedServiceServer) Invoke(context.Context, *InvokeRequest) (*InvokeResponse, error) {
+	return nil, grpcNotImplemented("Invoke")
+}
+
+func grpcNotImplemented(method string) error {
+	return status.Errorf(codes.Unimplemented, "method %s not implemented", method)
+}
+
+func RegisterServiceServer(s grpc.ServiceRegistrar, srv ServiceServer) {
+	s.RegisterService(&_Service_serviceDesc, srv)
+}
+
+// Service_EventsServer is the server-side stream handle passed to Events.
+type Service_EventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type serviceEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/pinkcore.v1.Service/Ping"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/pinkcore.v1.Service/Stop"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Stop(ctx, req.(*StopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_Dialog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DialogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Dialog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/pinkcore.v1.Service/Dialog"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Dialog(ctx, req.(*DialogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/pinkcore.v1.Service/Health"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_Invoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Invoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/pinkcore.v1.Service/Invoke"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Invoke(ctx, req.(*InvokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).Events(m, &serviceEventsServer{stream})
+}
+
+var _Service_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pinkcore.v1.Service",
+	HandlerType: (*ServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Ping", Handler: _Service_Ping_Handler},
+		{MethodName: "Stop", Handler: _Service_Stop_Handler},
+		{MethodName: "Dialog", Handler: _Service_Dialog_Handler},
+		{MethodName: "Health", Handler: _Service_Health_Handler},
+		{MethodName: "Invoke", Handler: _Service_Invoke_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "Events", Handler: _Service_Events_Handler, ServerStreams: true},
+	},
+	Metadata: "service.proto",
+}
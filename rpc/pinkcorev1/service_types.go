@@ -0,0 +1,49 @@
+// Package pinkcorev1 hand-maintains the Go types for pink-core's IPC
+// service. service.proto documents the schema for Go readers of this
+// package, not as a cross-language IDL, since these structs are not
+// protoc-gen-go output: the wire format is not real protobuf, so there is
+// nothing for protoc to regenerate here. See codec.go for how they're
+// actually put on the wire.
+package pinkcorev1
+
+type PingRequest struct{}
+
+type PingResponse struct{}
+
+type StopRequest struct{}
+
+type StopResponse struct{}
+
+type DialogRequest struct {
+	DialogJson []byte
+}
+
+type DialogResponse struct {
+	Choice string
+}
+
+type HealthRequest struct{}
+
+type HealthResponse struct {
+	Healthy       bool
+	Version       string
+	UptimeSeconds int64
+}
+
+type EventsRequest struct{}
+
+type Event struct {
+	TimestampUnix int64
+	Level         string
+	Body          string
+}
+
+type InvokeRequest struct {
+	Name    string
+	Payload []byte
+}
+
+type InvokeResponse struct {
+	Payload []byte
+	Error   string
+}
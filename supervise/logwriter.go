@@ -0,0 +1,35 @@
+package supervise
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/pink-tools/pink-core/log"
+)
+
+// lineWriter splits a subprocess's output into lines and forwards each one
+// to the existing log package, tagged with the child's name.
+type lineWriter struct {
+	logFn func(ctx context.Context, body string, attrs ...log.Attr)
+	ctx   context.Context
+	name  string
+	w     *io.PipeWriter
+}
+
+// newLineWriter returns an io.Writer that logs each line written to it via
+// logFn (log.Info or log.Warn), attributed to the named child.
+func newLineWriter(ctx context.Context, name string, logFn func(ctx context.Context, body string, attrs ...log.Attr)) io.Writer {
+	r, w := io.Pipe()
+	lw := &lineWriter{logFn: logFn, ctx: ctx, name: name, w: w}
+
+	go func() {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+		for scanner.Scan() {
+			lw.logFn(lw.ctx, scanner.Text(), log.Attr{"child", lw.name})
+		}
+	}()
+
+	return w
+}
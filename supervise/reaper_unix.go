@@ -0,0 +1,114 @@
+//go:build unix
+
+package supervise
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// The reaper turns SIGCHLD into exit notifications for supervised children.
+// A single global handler wakes on every SIGCHLD and probes only the pids
+// registered via watch, each with a targeted, non-blocking Wait4(pid, ...).
+//
+// It deliberately does NOT wait4(-1, ...): that call reaps the next exited
+// child of this process regardless of who started it, which would steal the
+// wait status of any plain os/exec child started elsewhere in the same
+// binary (outside supervise) and make that caller's own Cmd.Wait fail with
+// "waitid: no child processes". A process wanting this package's reaping to
+// be exhaustive instead of best-effort-per-registered-pid would need a real
+// subreaper (PR_SET_CHILD_SUBREAPER) and to route all its child processes
+// through supervise.Spawn; as written, supervise only ever reaps pids it was
+// given, and takes no stance on children started elsewhere in the process.
+var (
+	reaperOnce sync.Once
+	reaperMu   sync.Mutex
+	waiting    = map[int]func(success bool){}
+)
+
+func startReaper() {
+	reaperOnce.Do(func() {
+		sigCh := make(chan os.Signal, 64)
+		signal.Notify(sigCh, syscall.SIGCHLD)
+		go func() {
+			for range sigCh {
+				reapAll()
+			}
+		}()
+	})
+}
+
+func reapAll() {
+	reaperMu.Lock()
+	pids := make([]int, 0, len(waiting))
+	for pid := range waiting {
+		pids = append(pids, pid)
+	}
+	reaperMu.Unlock()
+
+	for _, pid := range pids {
+		reapOne(pid)
+	}
+}
+
+// reapOne does a single non-blocking Wait4 for pid and, if it has exited,
+// removes it from waiting and fires its onExit callback. Safe to call
+// whether or not pid has actually exited yet.
+func reapOne(pid int) {
+	var ws syscall.WaitStatus
+	got, err := syscall.Wait4(pid, &ws, syscall.WNOHANG, nil)
+	if err != nil || got != pid {
+		return // not this pid's SIGCHLD, or it hasn't exited yet
+	}
+
+	reaperMu.Lock()
+	onExit, ok := waiting[pid]
+	if ok {
+		delete(waiting, pid)
+	}
+	reaperMu.Unlock()
+
+	if ok {
+		onExit(ws.Exited() && ws.ExitStatus() == 0)
+	}
+}
+
+// watch registers cmd's pid with the reaper; onExit is called exactly once,
+// with true on a clean exit and false otherwise.
+//
+// cmd.Start() has already returned by the time watch runs, so the child may
+// have already exited and been signaled via SIGCHLD before it was in
+// waiting for reapAll to find: reapAll never retries a pid it didn't
+// recognize, so that notification would otherwise be lost forever, with
+// nothing else left to re-trigger a scan for this pid. Registering and then
+// immediately re-checking with a non-blocking Wait4 closes that window.
+func watch(cmd *exec.Cmd, onExit func(success bool)) {
+	startReaper()
+
+	reaperMu.Lock()
+	waiting[cmd.Process.Pid] = onExit
+	reaperMu.Unlock()
+
+	reapOne(cmd.Process.Pid)
+}
+
+func platformStart(cmd *exec.Cmd) error {
+	// New process group so Stop/Kill can be extended to the whole tree
+	// later without also taking down the parent daemon.
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	return cmd.Start()
+}
+
+func platformTerminate(p *os.Process) {
+	p.Signal(syscall.SIGTERM)
+}
+
+func platformKill(p *os.Process) {
+	p.Signal(syscall.SIGKILL)
+}
@@ -0,0 +1,80 @@
+//go:build windows
+
+package supervise
+
+import (
+	"os"
+	"os/exec"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Windows has no SIGCHLD; instead every supervised child is assigned to a
+// job object configured to kill all member processes when its last handle
+// closes, so children die with the daemon even if we crash ungracefully.
+var (
+	jobOnce sync.Once
+	job     windows.Handle
+)
+
+func jobObject() windows.Handle {
+	jobOnce.Do(func() {
+		h, err := windows.CreateJobObject(nil, nil)
+		if err != nil {
+			return
+		}
+
+		info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
+			BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
+				LimitFlags: windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE,
+			},
+		}
+		windows.SetInformationJobObject(
+			h,
+			windows.JobObjectExtendedLimitInformation,
+			uintptr(unsafe.Pointer(&info)),
+			uint32(unsafe.Sizeof(info)),
+		)
+
+		job = h
+	})
+	return job
+}
+
+func platformStart(cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	if h := jobObject(); h != 0 {
+		proc, err := windows.OpenProcess(windows.PROCESS_ALL_ACCESS, false, uint32(cmd.Process.Pid))
+		if err == nil {
+			windows.AssignProcessToJobObject(h, proc)
+			windows.CloseHandle(proc)
+		}
+	}
+
+	return nil
+}
+
+// watch reaps cmd's exit the normal Go way; there's no SIGCHLD to hook, and
+// job objects only guarantee children die, not that we're notified.
+func watch(cmd *exec.Cmd, onExit func(success bool)) {
+	go func() {
+		err := cmd.Wait()
+		onExit(err == nil)
+	}()
+}
+
+func platformTerminate(p *os.Process) {
+	// Windows has no graceful SIGTERM equivalent for arbitrary processes;
+	// go straight to termination and rely on the job object for any
+	// grandchildren.
+	p.Kill()
+}
+
+func platformKill(p *os.Process) {
+	p.Kill()
+}
@@ -0,0 +1,254 @@
+// Package supervise runs and supervises helper subprocesses (browsers,
+// ffmpeg, model runners) spawned by a pink-tools daemon. It reaps them
+// reliably, restarts them per a configurable policy, and makes sure none
+// are left behind when the daemon shuts down.
+package supervise
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/pink-tools/pink-core/log"
+)
+
+// RestartPolicy controls what happens when a supervised child exits.
+type RestartPolicy int
+
+const (
+	// Never restarts the child no matter how it exits.
+	Never RestartPolicy = iota
+	// OnFailure restarts the child only when it exits with a non-zero
+	// status or is killed by a signal.
+	OnFailure
+	// Always restarts the child regardless of exit status.
+	Always
+)
+
+// Options configures a supervised child.
+type Options struct {
+	Restart      RestartPolicy
+	MinBackoff   time.Duration // default 500ms
+	MaxBackoff   time.Duration // default 30s
+	GraceTimeout time.Duration // SIGTERM-to-SIGKILL grace period, default 5s
+}
+
+func (o Options) withDefaults() Options {
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = 500 * time.Millisecond
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 30 * time.Second
+	}
+	if o.GraceTimeout <= 0 {
+		o.GraceTimeout = 5 * time.Second
+	}
+	return o
+}
+
+// Child is a supervised subprocess.
+type Child struct {
+	name string
+	opts Options
+
+	newCmd func() *exec.Cmd // rebuilds the *exec.Cmd for each (re)start
+
+	mu          sync.Mutex
+	cmd         *exec.Cmd
+	attemptDone chan struct{} // closed when the current attempt's process exits
+	lastOK      bool
+	stopped     bool
+	done        chan struct{} // closed once supervision ends for good
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[*Child]struct{}{}
+)
+
+// Spawn starts cmd under supervision and returns immediately; the child
+// runs and, per opts.Restart, is restarted in the background. cmd is
+// started as-is the first time; on restart an equivalent command is
+// re-created from cmd.Path/Args/Env/Dir, since exec.Cmd cannot be reused.
+func Spawn(ctx context.Context, cmd *exec.Cmd, opts Options) (*Child, error) {
+	opts = opts.withDefaults()
+
+	name := cmd.Path
+	if len(cmd.Args) > 0 {
+		name = cmd.Args[0]
+	}
+
+	c := &Child{
+		name:   name,
+		opts:   opts,
+		newCmd: cloner(cmd),
+		done:   make(chan struct{}),
+	}
+
+	if err := c.start(ctx); err != nil {
+		return nil, err
+	}
+
+	registryMu.Lock()
+	registry[c] = struct{}{}
+	registryMu.Unlock()
+
+	go c.supervise(ctx)
+
+	return c, nil
+}
+
+// cloner captures cmd's construction: the returned func yields cmd itself on
+// its first call and a fresh *exec.Cmd on every later one, so restarts get
+// a new command (exec.Cmd is single-use once Start has been called).
+func cloner(cmd *exec.Cmd) func() *exec.Cmd {
+	path, args, dir, env := cmd.Path, cmd.Args, cmd.Dir, cmd.Env
+	first := cmd
+	return func() *exec.Cmd {
+		if first != nil {
+			orig := first
+			first = nil
+			return orig
+		}
+		fresh := exec.Command(path, args[1:]...)
+		fresh.Dir = dir
+		fresh.Env = env
+		return fresh
+	}
+}
+
+func (c *Child) start(ctx context.Context) error {
+	cmd := c.newCmd()
+	cmd.Stdout = newLineWriter(ctx, c.name, log.Info)
+	cmd.Stderr = newLineWriter(ctx, c.name, log.Warn)
+
+	if err := platformStart(cmd); err != nil {
+		return fmt.Errorf("start %s: %w", c.name, err)
+	}
+
+	attemptDone := make(chan struct{})
+
+	c.mu.Lock()
+	c.cmd = cmd
+	c.attemptDone = attemptDone
+	c.mu.Unlock()
+
+	watch(cmd, func(success bool) {
+		c.mu.Lock()
+		c.lastOK = success
+		c.mu.Unlock()
+		close(attemptDone)
+	})
+
+	return nil
+}
+
+func (c *Child) supervise(ctx context.Context) {
+	defer close(c.done)
+	defer func() {
+		registryMu.Lock()
+		delete(registry, c)
+		registryMu.Unlock()
+	}()
+	backoff := c.opts.MinBackoff
+
+	for {
+		c.mu.Lock()
+		attemptDone := c.attemptDone
+		c.mu.Unlock()
+		<-attemptDone
+
+		c.mu.Lock()
+		stopped, ok := c.stopped, c.lastOK
+		c.mu.Unlock()
+		if stopped {
+			return
+		}
+
+		restart := c.opts.Restart == Always || (c.opts.Restart == OnFailure && !ok)
+		if !restart {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(jitter(backoff)):
+		}
+
+		c.mu.Lock()
+		stopped = c.stopped
+		c.mu.Unlock()
+		if stopped {
+			return
+		}
+
+		log.Warn(ctx, "restarting supervised child", log.Attr{"name", c.name})
+		if err := c.start(ctx); err != nil {
+			log.Error(ctx, "failed to restart supervised child", log.Attr{"name", c.name}, log.Attr{"error", err.Error()})
+			return
+		}
+
+		backoff *= 2
+		if backoff > c.opts.MaxBackoff {
+			backoff = c.opts.MaxBackoff
+		}
+	}
+}
+
+func jitter(d time.Duration) time.Duration {
+	return d/2 + time.Duration(rand.Int63n(int64(d/2+1)))
+}
+
+// Stop sends SIGTERM to the child and escalates to SIGKILL after
+// opts.GraceTimeout if it hasn't exited. It blocks until the process is gone.
+func (c *Child) Stop() {
+	c.mu.Lock()
+	c.stopped = true
+	cmd := c.cmd
+	attemptDone := c.attemptDone
+	c.mu.Unlock()
+
+	if cmd == nil || cmd.Process == nil {
+		return
+	}
+
+	platformTerminate(cmd.Process)
+
+	select {
+	case <-attemptDone:
+	case <-time.After(c.opts.GraceTimeout):
+		platformKill(cmd.Process)
+		<-attemptDone
+	}
+}
+
+// Wait blocks until the child has exited for good (no further restarts).
+func (c *Child) Wait() {
+	<-c.done
+}
+
+// Shutdown stops every supervised child, in parallel, and waits for all of
+// them to exit or be killed. Run calls this during daemon shutdown so no
+// helper process outlives its parent.
+func Shutdown() {
+	registryMu.Lock()
+	children := make([]*Child, 0, len(registry))
+	for c := range registry {
+		children = append(children, c)
+	}
+	registryMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, c := range children {
+		wg.Add(1)
+		go func(c *Child) {
+			defer wg.Done()
+			c.Stop()
+		}(c)
+	}
+	wg.Wait()
+}
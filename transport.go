@@ -0,0 +1,32 @@
+package core
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// listenTCPFallback binds the old loopback-TCP-plus-port-file transport,
+// used on platforms or filesystems where the preferred control-socket
+// transport (unix socket / named pipe) isn't available.
+func listenTCPFallback(name string) (net.Listener, string, string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, "", "", fmt.Errorf("listen: %w", err)
+	}
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	portFile := portFilePath(name)
+	if err := os.MkdirAll(filepath.Dir(portFile), 0755); err != nil {
+		listener.Close()
+		return nil, "", "", fmt.Errorf("mkdir: %w", err)
+	}
+	if err := os.WriteFile(portFile, []byte(strconv.Itoa(port)), 0644); err != nil {
+		listener.Close()
+		return nil, "", "", fmt.Errorf("write port file: %w", err)
+	}
+
+	return listener, "tcp", fmt.Sprintf("127.0.0.1:%d", port), nil
+}
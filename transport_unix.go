@@ -0,0 +1,100 @@
+//go:build unix
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/grpc"
+
+	"github.com/pink-tools/pink-core/log"
+)
+
+// socketPath returns the control-socket path for a service:
+// /Users/pink-tools/{name}/{name}.sock
+func socketPath(name string) string {
+	return filepath.Join(ServiceDir(name), name+".sock")
+}
+
+// platformListen binds the service's control socket, preferring a
+// 0600 unix socket and falling back to TCP with a port file when the
+// unix socket can't be created (e.g. the data dir is on a filesystem
+// that doesn't support them).
+func platformListen(name string) (net.Listener, string, string, error) {
+	path := socketPath(name)
+	os.Remove(path) // clear a stale socket from an unclean shutdown
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return listenTCPFallback(name)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, "", "", fmt.Errorf("chmod socket: %w", err)
+	}
+	return listener, "unix", path, nil
+}
+
+// platformDial resolves the gRPC dial target for a service, preferring its
+// unix socket and falling back to the TCP port file.
+func platformDial(name string) (string, []grpc.DialOption, error) {
+	path := socketPath(name)
+	if _, err := os.Stat(path); err == nil {
+		return "unix:" + path, nil, nil
+	}
+
+	port, err := readPort(name)
+	if err != nil {
+		return "", nil, fmt.Errorf("not running: %w", err)
+	}
+	return fmt.Sprintf("127.0.0.1:%d", port), nil, nil
+}
+
+// authListener wraps a unix socket listener so that only connections from
+// an allowed UID are handed to the gRPC server; everyone else is dropped
+// before a single byte of the protocol is read.
+type authListener struct {
+	net.Listener
+	allowed map[int]bool
+}
+
+// maybeWrapAuth enforces peer-UID checks when the listener is a unix
+// socket; our own UID is always allowed in addition to extraUIDs. Other
+// transports (the TCP fallback) are returned unchanged.
+func maybeWrapAuth(l net.Listener, network string, extraUIDs []int) net.Listener {
+	if network != "unix" {
+		return l
+	}
+
+	allowed := map[int]bool{os.Getuid(): true}
+	for _, uid := range extraUIDs {
+		allowed[uid] = true
+	}
+	return &authListener{Listener: l, allowed: allowed}
+}
+
+func (l *authListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		uid, err := peerUID(conn)
+		if err != nil {
+			log.Warn(context.Background(), "could not verify IPC peer credentials", log.Attr{"error", err.Error()})
+			conn.Close()
+			continue
+		}
+		if !l.allowed[uid] {
+			log.Warn(context.Background(), "rejected IPC connection from disallowed uid", log.Attr{"uid", fmt.Sprint(uid)})
+			conn.Close()
+			continue
+		}
+		return conn, nil
+	}
+}
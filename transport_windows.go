@@ -0,0 +1,58 @@
+//go:build windows
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// pipePath returns the named-pipe path for a service's control channel.
+func pipePath(name string) string {
+	return `\\.\pipe\pink-tools-` + name
+}
+
+// platformListen binds the service's named pipe, falling back to TCP with
+// a port file if the pipe can't be created.
+func platformListen(name string) (net.Listener, string, string, error) {
+	listener, err := winio.ListenPipe(pipePath(name), &winio.PipeConfig{
+		// Restrict to the current user; there is no UID to check on
+		// accept like on Unix, so access control happens at pipe-ACL time.
+		SecurityDescriptor: "D:P(A;;GA;;;OW)",
+	})
+	if err != nil {
+		return listenTCPFallback(name)
+	}
+	return listener, "pipe", pipePath(name), nil
+}
+
+// maybeWrapAuth is a no-op on Windows: access control happens via the
+// pipe's security descriptor at creation time instead of a per-connection
+// credential check.
+func maybeWrapAuth(l net.Listener, network string, extraUIDs []int) net.Listener {
+	return l
+}
+
+// platformDial resolves the gRPC dial target for a service, preferring its
+// named pipe and falling back to the TCP port file.
+func platformDial(name string) (string, []grpc.DialOption, error) {
+	path := pipePath(name)
+	if _, err := os.Stat(path); err == nil {
+		dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+			return winio.DialPipeContext(ctx, addr)
+		}
+		return path, []grpc.DialOption{grpc.WithContextDialer(dialer)}, nil
+	}
+
+	port, err := readPort(name)
+	if err != nil {
+		return "", nil, fmt.Errorf("not running: %w", err)
+	}
+	return fmt.Sprintf("127.0.0.1:%d", port), nil, nil
+}
@@ -0,0 +1,225 @@
+package core
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/pink-tools/pink-core/log"
+)
+
+// UpdateOptions configures EnableSelfUpdate.
+type UpdateOptions struct {
+	ManifestURL   string            // where to fetch the signed update manifest
+	PublicKey     ed25519.PublicKey // baked into the binary; verifies ManifestURL's signature
+	CheckInterval time.Duration     // background check cadence while running as a daemon, default 1h
+	HealthTimeout time.Duration     // time a respawned binary has to prove it's alive, default 10s
+}
+
+func (o UpdateOptions) withDefaults() UpdateOptions {
+	if o.CheckInterval <= 0 {
+		o.CheckInterval = time.Hour
+	}
+	if o.HealthTimeout <= 0 {
+		o.HealthTimeout = 10 * time.Second
+	}
+	return o
+}
+
+// updateManifest is the signed payload served from UpdateOptions.ManifestURL.
+type updateManifest struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+	URL        string `json:"url"`
+	SHA256     string `json:"sha256"`
+	MinVersion string `json:"minVersion"`
+	Sig        string `json:"sig"` // base64 ed25519 signature over the manifest with Sig itself cleared
+}
+
+// selfUpdateOpts records the options passed to EnableSelfUpdate so Run can
+// start the background checker, the startup rollback check and the
+// post-shutdown re-exec.
+// pink-tools binaries only ever build one Config, so a package var is fine.
+var selfUpdateOpts *UpdateOptions
+
+// EnableSelfUpdate registers an "update" subcommand on cfg and, once running
+// as a daemon, a periodic background check every opts.CheckInterval. Updates
+// are applied by swapping BinaryPath(cfg.Name), asking the running daemon
+// (if any) to stop via the existing IPC Stop RPC, and letting Run's shutdown
+// path re-exec into the new binary once it has confirmed the swap.
+func EnableSelfUpdate(cfg *Config, opts UpdateOptions) {
+	opts = opts.withDefaults()
+	selfUpdateOpts = &opts
+
+	if cfg.Commands == nil {
+		cfg.Commands = map[string]Command{}
+	}
+	cfg.Commands["update"] = Command{
+		Desc: "Check for and install an update",
+		Run: func(args []string) error {
+			return checkAndInstallUpdate(context.Background(), *cfg, opts)
+		},
+	}
+}
+
+// runUpdateChecker polls for updates every opts.CheckInterval until ctx is
+// done. Run starts this in daemon mode when EnableSelfUpdate has been called.
+func runUpdateChecker(ctx context.Context, cfg Config, opts UpdateOptions) {
+	ticker := time.NewTicker(opts.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := checkAndInstallUpdate(ctx, cfg, opts); err != nil {
+				log.Warn(ctx, "self-update check failed", log.Attr{"error", err.Error()})
+			}
+		}
+	}
+}
+
+// checkAndInstallUpdate fetches and verifies the manifest, and if it
+// describes a newer version, downloads, verifies and installs it.
+func checkAndInstallUpdate(ctx context.Context, cfg Config, opts UpdateOptions) error {
+	manifest, err := fetchManifest(opts.ManifestURL, opts.PublicKey)
+	if err != nil {
+		return fmt.Errorf("fetch manifest: %w", err)
+	}
+
+	if manifest.OS != runtime.GOOS || manifest.Arch != runtime.GOARCH {
+		return fmt.Errorf("manifest is for %s/%s, not %s/%s", manifest.OS, manifest.Arch, runtime.GOOS, runtime.GOARCH)
+	}
+
+	if !versionNewer(manifest.Version, cfg.Version) {
+		log.Debug(ctx, "already up to date", log.Attr{"version", cfg.Version})
+		return nil
+	}
+
+	if manifest.MinVersion != "" && versionNewer(manifest.MinVersion, cfg.Version) {
+		return fmt.Errorf("running version %s is too old to update directly; need at least %s first", cfg.Version, manifest.MinVersion)
+	}
+
+	log.Info(ctx, "installing update", log.Attr{"from", cfg.Version}, log.Attr{"to", manifest.Version})
+
+	data, err := downloadAndVerify(manifest.URL, manifest.SHA256)
+	if err != nil {
+		return fmt.Errorf("download: %w", err)
+	}
+
+	newPath := filepath.Join(ServiceDir(cfg.Name), cfg.Name+".new")
+	if err := os.WriteFile(newPath, data, 0755); err != nil {
+		return fmt.Errorf("write new binary: %w", err)
+	}
+
+	if err := swapBinary(cfg.Name, newPath); err != nil {
+		return fmt.Errorf("swap binary: %w", err)
+	}
+
+	if err := writeUpdateMarker(cfg.Name, opts.HealthTimeout); err != nil {
+		log.Warn(ctx, "failed to write update marker; rollback on crash won't trigger", log.Attr{"error", err.Error()})
+	}
+
+	if IsRunning(cfg.Name) {
+		if err := SendStop(cfg.Name); err != nil {
+			log.Warn(ctx, "installed update but failed to stop the running daemon", log.Attr{"error", err.Error()})
+		}
+	}
+
+	return nil
+}
+
+func fetchManifest(url string, pub ed25519.PublicKey) (*updateManifest, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	var m updateManifest
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(m.Sig)
+	if err != nil {
+		return nil, fmt.Errorf("decode signature: %w", err)
+	}
+
+	unsigned := m
+	unsigned.Sig = ""
+	canonical, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ed25519.Verify(pub, canonical, sig) {
+		return nil, fmt.Errorf("manifest signature verification failed")
+	}
+
+	return &m, nil
+}
+
+func downloadAndVerify(url, wantSHA256 string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != wantSHA256 {
+		return nil, fmt.Errorf("sha256 mismatch")
+	}
+
+	return data, nil
+}
+
+// swapBinary moves the current binary aside to a ".old" sidecar (since the
+// running exe is locked on Windows, and for rollback on every platform) and
+// moves newPath into its place.
+func swapBinary(name, newPath string) error {
+	current := BinaryPath(name)
+	old := current + ".old"
+
+	os.Remove(old)
+	if err := os.Rename(current, old); err != nil {
+		return fmt.Errorf("back up current binary: %w", err)
+	}
+	if err := os.Rename(newPath, current); err != nil {
+		os.Rename(old, current) // best-effort restore
+		return fmt.Errorf("install new binary: %w", err)
+	}
+	return os.Chmod(current, 0755)
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pink-tools/pink-core/log"
+)
+
+// updateMarker records an in-progress self-update so that, across process
+// restarts, we can tell whether the new binary ever proved itself healthy.
+// StartedAt is zero until the freshly respawned binary first observes the
+// marker; HealthTimeout only starts counting down from then, not from when
+// the old binary wrote the marker, since that old binary's own graceful
+// shutdown (PreStop/Stop/PostStop/Flush hooks, each up to --drain-timeout) can
+// take nearly as long as HealthTimeout all by itself.
+type updateMarker struct {
+	OldPath       string        `json:"oldPath"`
+	HealthTimeout time.Duration `json:"healthTimeout"`
+	StartedAt     time.Time     `json:"startedAt"`
+}
+
+func updateMarkerPath(name string) string {
+	return filepath.Join(ServiceDir(name), name+".update-pending")
+}
+
+func writeUpdateMarker(name string, healthTimeout time.Duration) error {
+	return writeUpdateMarkerValue(name, &updateMarker{
+		OldPath:       BinaryPath(name) + ".old",
+		HealthTimeout: healthTimeout,
+	})
+}
+
+func writeUpdateMarkerValue(name string, m *updateMarker) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(updateMarkerPath(name), data, 0644)
+}
+
+func readUpdateMarker(name string) (*updateMarker, error) {
+	data, err := os.ReadFile(updateMarkerPath(name))
+	if err != nil {
+		return nil, err
+	}
+	var m updateMarker
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// resolvePendingSelfUpdate is called once near the top of Run's daemon mode.
+// If a self-update marker is present, this process runs the freshly
+// installed binary. If HealthTimeout has already passed since that binary
+// first started without it confirming health (e.g. it crashed), roll back
+// to the backed-up binary and re-exec it; otherwise confirm health in the
+// background, rolling back if the deadline passes first.
+func resolvePendingSelfUpdate(ctx context.Context, name string) {
+	marker, err := readUpdateMarker(name)
+	if err != nil {
+		return
+	}
+
+	if marker.StartedAt.IsZero() {
+		marker.StartedAt = time.Now()
+		if err := writeUpdateMarkerValue(name, marker); err != nil {
+			log.Warn(ctx, "failed to record self-update start time", log.Attr{"error", err.Error()})
+		}
+	}
+
+	deadline := marker.StartedAt.Add(marker.HealthTimeout)
+	if time.Now().After(deadline) {
+		rollbackSelfUpdate(ctx, name, marker)
+		return
+	}
+
+	go confirmSelfUpdateHealth(ctx, name, marker, deadline)
+}
+
+// confirmSelfUpdateHealth polls this daemon's own Health RPC (which won't
+// answer until startIPCListener has run, shortly after this goroutine
+// starts) until it reports healthy, the deadline passes, or ctx is done.
+func confirmSelfUpdateHealth(ctx context.Context, name string, marker *updateMarker, deadline time.Time) {
+	const pollInterval = 500 * time.Millisecond
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Didn't survive to confirm; the marker is left in place so the
+			// next start rolls back if the deadline has passed by then, and
+			// otherwise keeps waiting for health until it does.
+			return
+		case <-timer.C:
+			log.Error(ctx, "self-update never confirmed healthy; rolling back", log.Attr{"oldPath", marker.OldPath})
+			rollbackSelfUpdate(ctx, name, marker)
+			return
+		case <-ticker.C:
+			if selfUpdateHealthy(name) {
+				log.Info(ctx, "self-update confirmed healthy")
+				os.Remove(updateMarkerPath(name))
+				os.Remove(marker.OldPath)
+				return
+			}
+		}
+	}
+}
+
+// selfUpdateHealthy dials this daemon's own IPC socket and asks its Health
+// RPC, a richer check than the Ping that --health and IsRunning use.
+func selfUpdateHealthy(name string) bool {
+	c, err := Dial(name)
+	if err != nil {
+		return false
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	resp, err := c.Health(ctx)
+	return err == nil && resp.Healthy
+}
+
+func rollbackSelfUpdate(ctx context.Context, name string, marker *updateMarker) {
+	if err := os.Rename(marker.OldPath, BinaryPath(name)); err != nil {
+		log.Error(ctx, "rollback failed", log.Attr{"error", err.Error()})
+		os.Remove(updateMarkerPath(name))
+		return
+	}
+	os.Remove(updateMarkerPath(name))
+	execSelf(name)
+}
+
+// requestedSelfRestart is set (via the update marker's presence) once Run's
+// shutdown sequence should re-exec into the freshly installed binary rather
+// than just exiting. It's checked, not stored in memory, because the
+// process requesting the stop may be a separate "update" CLI invocation.
+func requestedSelfRestart(name string) bool {
+	_, err := os.Stat(updateMarkerPath(name))
+	return err == nil
+}
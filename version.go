@@ -0,0 +1,44 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+)
+
+// versionNewer reports whether a is strictly newer than b, comparing
+// dotted numeric version strings (with an optional leading "v") component
+// by component. Non-numeric components compare as 0, which is good enough
+// for the update manifest's version/minVersion fields.
+func versionNewer(a, b string) bool {
+	return compareVersions(a, b) > 0
+}
+
+func compareVersions(a, b string) int {
+	as := versionParts(a)
+	bs := versionParts(b)
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var av, bv int
+		if i < len(as) {
+			av = as[i]
+		}
+		if i < len(bs) {
+			bv = bs[i]
+		}
+		if av != bv {
+			return av - bv
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(v, "v")
+	fields := strings.Split(v, ".")
+	parts := make([]int, len(fields))
+	for i, f := range fields {
+		n, _ := strconv.Atoi(f)
+		parts[i] = n
+	}
+	return parts
+}